@@ -0,0 +1,96 @@
+package cmndr
+
+import "github.com/pkg/errors"
+
+// PositionalArgsFunc validates the positional arguments left over after a
+// Cmd's flags have been parsed. See Cmd.Args.
+type PositionalArgsFunc func(cmd *Cmd, args []string) error
+
+// NoArgs requires that a command be run with no positional arguments.
+func NoArgs(cmd *Cmd, args []string) error {
+	if len(args) > 0 {
+		return errors.Errorf("%s accepts no arguments, got %d", cmd.Name, len(args))
+	}
+	return nil
+}
+
+// ArbitraryArgs accepts any number of positional arguments.
+func ArbitraryArgs(cmd *Cmd, args []string) error {
+	return nil
+}
+
+// MinimumNArgs returns an Args validator requiring at least n positional
+// arguments.
+func MinimumNArgs(n int) PositionalArgsFunc {
+	return func(cmd *Cmd, args []string) error {
+		if len(args) < n {
+			return errors.Errorf("%s requires at least %d arg(s), got %d", cmd.Name, n, len(args))
+		}
+		return nil
+	}
+}
+
+// MaximumNArgs returns an Args validator requiring at most n positional
+// arguments.
+func MaximumNArgs(n int) PositionalArgsFunc {
+	return func(cmd *Cmd, args []string) error {
+		if len(args) > n {
+			return errors.Errorf("%s accepts at most %d arg(s), got %d", cmd.Name, n, len(args))
+		}
+		return nil
+	}
+}
+
+// ExactArgs returns an Args validator requiring exactly n positional
+// arguments.
+func ExactArgs(n int) PositionalArgsFunc {
+	return func(cmd *Cmd, args []string) error {
+		if len(args) != n {
+			return errors.Errorf("%s requires exactly %d arg(s), got %d", cmd.Name, n, len(args))
+		}
+		return nil
+	}
+}
+
+// RangeArgs returns an Args validator requiring between min and max
+// (inclusive) positional arguments.
+func RangeArgs(min, max int) PositionalArgsFunc {
+	return func(cmd *Cmd, args []string) error {
+		if len(args) < min || len(args) > max {
+			return errors.Errorf("%s requires between %d and %d arg(s), got %d", cmd.Name, min, max, len(args))
+		}
+		return nil
+	}
+}
+
+// OnlyValidArgs returns an error if any positional argument is not present
+// in cmd.ValidArgs.
+func OnlyValidArgs(cmd *Cmd, args []string) error {
+	for _, arg := range args {
+		var valid bool
+		for _, va := range cmd.ValidArgs {
+			if va == arg {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return errors.Errorf("%s: invalid argument %q", cmd.Name, arg)
+		}
+	}
+	return nil
+}
+
+// MatchAll returns an Args validator that passes only if every one of the
+// given validators passes, so that, e.g., MinimumNArgs and OnlyValidArgs can
+// be enforced together.
+func MatchAll(validators ...PositionalArgsFunc) PositionalArgsFunc {
+	return func(cmd *Cmd, args []string) error {
+		for _, v := range validators {
+			if err := v(cmd, args); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+package cmndr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPositionalArgValidators(t *testing.T) {
+	cmd := &Cmd{Name: "greet", ValidArgs: []string{"alice", "bob"}}
+
+	tests := []struct {
+		name      string
+		validator PositionalArgsFunc
+		args      []string
+		wantErr   bool
+	}{
+		{"NoArgs ok", NoArgs, nil, false},
+		{"NoArgs fail", NoArgs, []string{"x"}, true},
+		{"ArbitraryArgs always ok", ArbitraryArgs, []string{"x", "y", "z"}, false},
+		{"MinimumNArgs ok", MinimumNArgs(2), []string{"a", "b"}, false},
+		{"MinimumNArgs fail", MinimumNArgs(2), []string{"a"}, true},
+		{"MaximumNArgs ok", MaximumNArgs(2), []string{"a", "b"}, false},
+		{"MaximumNArgs fail", MaximumNArgs(2), []string{"a", "b", "c"}, true},
+		{"ExactArgs ok", ExactArgs(1), []string{"a"}, false},
+		{"ExactArgs fail", ExactArgs(1), nil, true},
+		{"RangeArgs ok", RangeArgs(1, 2), []string{"a"}, false},
+		{"RangeArgs fail", RangeArgs(1, 2), []string{"a", "b", "c"}, true},
+		{"OnlyValidArgs ok", OnlyValidArgs, []string{"alice"}, false},
+		{"OnlyValidArgs fail", OnlyValidArgs, []string{"carol"}, true},
+		{"MatchAll ok", MatchAll(ExactArgs(1), OnlyValidArgs), []string{"bob"}, false},
+		{"MatchAll fail on first", MatchAll(ExactArgs(1), OnlyValidArgs), []string{"bob", "alice"}, true},
+		{"MatchAll fail on second", MatchAll(ExactArgs(1), OnlyValidArgs), []string{"carol"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validator(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddCmdWiresSubcommandUsage(t *testing.T) {
+	root := New("root", nil)
+	leaf := &Cmd{Name: "greet", Description: "Greet someone", Args: ExactArgs(1)}
+	root.AddCmd(leaf)
+
+	if leaf.Flags == nil {
+		t.Fatal("AddCmd should create a Flags set when none was provided")
+	}
+
+	out := captureStderr(t, func() { leaf.Flags.Usage() })
+
+	if !strings.Contains(out, "greet - Greet someone") {
+		t.Errorf("usage output should describe the subcommand itself, got:\n%s", out)
+	}
+	if strings.Contains(out, "Usage of") {
+		t.Errorf("usage output should not fall back to the stdlib default, got:\n%s", out)
+	}
+}
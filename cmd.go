@@ -29,8 +29,212 @@ type Cmd struct {
 	// method.
 	Commands map[string]*Cmd
 
+	// Groups lists the command groups that have been registered on c,
+	// via AddGroup, for the purposes of bucketing its subcommands in
+	// help output. See Cmd.GroupID.
+	Groups []*Group
+
+	// GroupID places c under the Group with the matching ID, in its
+	// parent's help output. Leave empty to fall under "Additional
+	// Commands".
+	GroupID string
+
+	// Hidden omits c from its parent's help output and "Did you mean"
+	// suggestions, without preventing it from being run.
+	Hidden bool
+
+	// Deprecated, when non-empty, marks c as deprecated, and is shown
+	// alongside c's Description in its parent's help output.
+	Deprecated string
+
+	// UsageTemplate, if set, overrides the default usage message
+	// rendered for c. It is parsed as a text/template, with c as the
+	// data value, plus the template functions documented alongside
+	// HelpTemplate.
+	UsageTemplate string
+
+	// HelpTemplate, if set, overrides the message printed by the "help"
+	// subcommand for c. It is parsed as a text/template, with c as the
+	// data value, and has access to the .HasAvailableSubCommands,
+	// .LocalFlags, and .InheritedFlags methods on *Cmd, plus the
+	// "subCommands" function (called as {{subCommands .}}, not
+	// {{.SubCommands}}, since Cmd already has a Commands field).
+	HelpTemplate string
+
+	// ValidArgs lists the accepted values for the command's positional
+	// arguments. It is used by the "completion" machinery to offer
+	// static argument completion, and (once set) by the OnlyValidArgs
+	// validator.
+	ValidArgs []string
+
+	// ValidArgsFunction, if set, is consulted by the "completion"
+	// machinery instead of ValidArgs, allowing a command to compute its
+	// argument completions dynamically (e.g. listing files, or querying
+	// a remote API).
+	ValidArgsFunction func(cmd *Cmd, args []string, toComplete string) ([]string, ShellCompDirective)
+
+	// Args validates the positional arguments left over after flag
+	// parsing. If it returns a non-nil error, ExecArgs prints the error
+	// along with the usage message, and exits with status code 2,
+	// without calling Run. See NoArgs, ExactArgs, MinimumNArgs, and
+	// friends for prebuilt validators.
+	Args PositionalArgsFunc
+
+	// SuggestionsMinimumDistance sets the maximum Levenshtein distance,
+	// from an unrecognized subcommand name, at which a registered
+	// subcommand is offered as a "Did you mean" suggestion. Defaults to
+	// 2 when left at its zero value.
+	SuggestionsMinimumDistance int
+
+	// DisableSuggestions turns off "Did you mean" suggestions for this
+	// command.
+	DisableSuggestions bool
+
 	// The function to run.
 	Run RunFunc
+
+	// PreRun is called before PreRunE and Run, after flags have been
+	// parsed. Prefer PreRunE if you need to return an error.
+	PreRun func(cmd *Cmd, args []string)
+
+	// PreRunE is called immediately before Run, after flags have been
+	// parsed. If it returns a non-nil error, Run is not called, and the
+	// error is surfaced the same way an error from Run would be.
+	PreRunE RunFunc
+
+	// PostRun is called immediately after Run completes successfully.
+	// Prefer PostRunE if you need to return an error.
+	PostRun func(cmd *Cmd, args []string)
+
+	// PostRunE is called after Run completes successfully, and after
+	// PostRun. A non-nil error is surfaced the same way an error from Run would
+	// be.
+	PostRunE RunFunc
+
+	// PersistentPreRunE is called before PreRunE, for c and every one of
+	// its descendants. If a descendant defines its own
+	// PersistentPreRunE, it takes precedence over one defined on an
+	// ancestor, matching the usual "nearest wins" semantics.
+	PersistentPreRunE RunFunc
+
+	// PersistentPostRunE is called after PostRunE, for c and every one
+	// of its descendants, with the same "nearest wins" semantics as
+	// PersistentPreRunE.
+	PersistentPostRunE RunFunc
+
+	// persistentFlags holds flags that should be visible to c and every
+	// one of its descendants. Access it through PersistentFlags().
+	persistentFlags *flag.FlagSet
+
+	// parent is set by AddCmd, and lets a Cmd walk up towards the root
+	// command, e.g. to gather inherited persistent flags.
+	parent *Cmd
+}
+
+// PersistentFlags returns the *flag.FlagSet holding the flags that cascade
+// down to c and all of its descendants, lazily creating it if necessary.
+func (c *Cmd) PersistentFlags() *flag.FlagSet {
+	if c.persistentFlags == nil {
+		c.persistentFlags = flag.NewFlagSet(c.Name, flag.ExitOnError)
+	}
+	return c.persistentFlags
+}
+
+// mergePersistentFlags copies any persistent flag, declared on c or one of
+// its ancestors, into c.Flags, so that a single c.Flags.Parse call also
+// recognizes inherited flags. Flags declared nearer to c take precedence
+// over those declared further up the chain.
+func mergePersistentFlags(c *Cmd) {
+	for p := c; p != nil; p = p.parent {
+		if p.persistentFlags == nil {
+			continue
+		}
+		p.persistentFlags.VisitAll(func(f *flag.Flag) {
+			if c.Flags.Lookup(f.Name) != nil {
+				return
+			}
+			c.Flags.Var(f.Value, f.Name, f.Usage)
+		})
+	}
+}
+
+// findPersistentPreRunE returns the PersistentPreRunE that should run for c:
+// the one defined nearest to c, searching from c itself up towards the
+// root.
+func findPersistentPreRunE(c *Cmd) RunFunc {
+	for p := c; p != nil; p = p.parent {
+		if p.PersistentPreRunE != nil {
+			return p.PersistentPreRunE
+		}
+	}
+	return nil
+}
+
+// findPersistentPostRunE returns the PersistentPostRunE that should run for
+// c, using the same "nearest wins" search as findPersistentPreRunE.
+func findPersistentPostRunE(c *Cmd) RunFunc {
+	for p := c; p != nil; p = p.parent {
+		if p.PersistentPostRunE != nil {
+			return p.PersistentPostRunE
+		}
+	}
+	return nil
+}
+
+// runWithHooks runs c.Run for the given args, surrounding it with c's
+// lifecycle hooks in the order: PersistentPreRunE, PreRun, PreRunE, Run,
+// PostRun, PostRunE, PersistentPostRunE. The first hook (or Run) to return
+// an error short-circuits the remaining ones.
+func (c *Cmd) runWithHooks(args []string) error {
+	if pre := findPersistentPreRunE(c); pre != nil {
+		if err := pre(c, args); err != nil {
+			return err
+		}
+	}
+	if c.PreRun != nil {
+		c.PreRun(c, args)
+	}
+	if c.PreRunE != nil {
+		if err := c.PreRunE(c, args); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Run(c, args); err != nil {
+		return err
+	}
+
+	if c.PostRun != nil {
+		c.PostRun(c, args)
+	}
+	if c.PostRunE != nil {
+		if err := c.PostRunE(c, args); err != nil {
+			return err
+		}
+	}
+	if post := findPersistentPostRunE(c); post != nil {
+		if err := post(c, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printGlobalFlags prints the "Global Flags" section of the usage message:
+// the persistent flags that c inherited from its ancestors.
+func printGlobalFlags(c *Cmd) {
+	flags := c.InheritedFlags()
+	if len(flags) == 0 {
+		return
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+
+	fmt.Fprintln(os.Stderr, "\nGlobal Flags")
+	tw := tabwriter.NewWriter(os.Stderr, 0, 4, 1, ' ', 0)
+	defer tw.Flush()
+	for _, f := range flags {
+		fmt.Fprintf(tw, "\t\t-%s\t%s\n", f.Name, f.Usage)
+	}
 }
 
 func newUsage(c *Cmd) func() {
@@ -38,10 +242,20 @@ func newUsage(c *Cmd) func() {
 		if c.Flags == nil {
 			c.Flags = flag.NewFlagSet(c.Name, flag.ExitOnError)
 		}
+		mergePersistentFlags(c)
+
+		if c.UsageTemplate != "" {
+			if err := renderTemplate(os.Stderr, "usage", c.UsageTemplate, c); err != nil {
+				fmt.Fprintln(os.Stderr, "error rendering usage template:", err)
+			}
+			return
+		}
+
 		fmt.Fprintf(os.Stderr, "%s - %s\n", c.Name, c.Description)
 		printSubcommands(c)
 		fmt.Fprintln(os.Stderr, "\nFlags")
 		c.Flags.PrintDefaults()
+		printGlobalFlags(c)
 	}
 }
 
@@ -80,9 +294,14 @@ func newHelpCmd(parent *Cmd) *Cmd {
 			// intended to print the help message of a subcommand,
 			// but that subcommand does not exist.
 			if pp == nil {
+				printSuggestions(parent, args[0])
 				return errors.Errorf("no such command: %q", args[0])
 			}
 
+			if pp.HelpTemplate != "" {
+				return renderTemplate(os.Stderr, "help", pp.HelpTemplate, pp)
+			}
+
 			if pp.Flags == nil {
 				fmt.Fprintf(os.Stderr, "%s - %s\n", pp.Name, pp.Description)
 				printSubcommands(pp)
@@ -94,30 +313,6 @@ func newHelpCmd(parent *Cmd) *Cmd {
 	}
 }
 
-// printSubcommands is a helper function, used when calling a "help"
-// subcommand; it prints all of the registered subcommands of c, if any.
-func printSubcommands(c *Cmd) {
-	if c.Commands == nil {
-		return
-	}
-
-	fmt.Fprintln(os.Stderr, "\nCommands")
-
-	// Gather a list of all subcommand names, and sort them (for
-	// consistent output).
-	var subNames []string
-	for name, _ := range c.Commands {
-		subNames = append(subNames, name)
-	}
-	sort.Strings(subNames)
-
-	tw := tabwriter.NewWriter(os.Stderr, 0, 4, 1, ' ', 0)
-	defer tw.Flush()
-	for _, name := range subNames {
-		fmt.Fprintf(tw, "\t\t%s\t%s\n", name, c.Commands[name].Description)
-	}
-}
-
 // New is a convenience function for creating and returning a new *Cmd.
 //
 // New will automatically add a "help" subcommand that, when called with no
@@ -154,7 +349,13 @@ func (c *Cmd) AddCmd(cmd *Cmd) {
 	if cmd.Name == "" {
 		panic("cannot add nameless subcommand")
 	}
+	cmd.parent = c
 	c.Commands[cmd.Name] = cmd
+
+	if cmd.Flags == nil {
+		cmd.Flags = flag.NewFlagSet(cmd.Name, flag.ExitOnError)
+	}
+	cmd.Flags.Usage = newUsage(cmd)
 }
 
 // Exec parses the arguments provided on the command line. This is the
@@ -176,11 +377,19 @@ func (c *Cmd) Exec() {
 // To customize the usage message that is printed, set c.Flags.Usage (refer to
 // the documentation for flag.FlagSet).
 func (c *Cmd) ExecArgs(args []string) {
-	// Make sure there is a non-nil flag set.
+	// Make sure there is a non-nil flag set. AddCmd already does this (and
+	// wires up newUsage) for every registered subcommand; this only
+	// covers c itself, when ExecArgs is called on a Cmd that was never
+	// passed to AddCmd (e.g. a root built by hand instead of via New()).
 	if c.Flags == nil {
 		c.Flags = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+		c.Flags.Usage = newUsage(c)
 	}
 
+	// Pull in any persistent flags declared on c or one of its
+	// ancestors, so that they can be parsed at this level too.
+	mergePersistentFlags(c)
+
 	// Parse the given arguments.
 	if err := c.Flags.Parse(args); err != nil {
 		fmt.Fprintln(os.Stderr, "error parsing arguments:", err)
@@ -190,6 +399,7 @@ func (c *Cmd) ExecArgs(args []string) {
 	// If we have some registered subcommands, and the first positional
 	// argument matches the name of one of the registered subcommands,
 	// execute it.
+	var unmatched string
 	if c.Commands != nil && c.Flags.Arg(0) != "" {
 		if sub, ok := c.Commands[c.Flags.Arg(0)]; ok {
 			// Our first positional argument refers to a registered
@@ -203,17 +413,34 @@ func (c *Cmd) ExecArgs(args []string) {
 			}
 			return
 		}
+		unmatched = c.Flags.Arg(0)
 	}
 
 	// No subcommand was provided, and our main RunFunc is nil. Print a
 	// usage message, and exit.
 	if c.Run == nil {
+		if unmatched != "" {
+			fmt.Fprintf(os.Stderr, "%s: no such command: %q\n", c.Name, unmatched)
+			printSuggestions(c, unmatched)
+		}
 		c.Flags.Usage()
 		os.Exit(1)
 	}
 
-	// Call our RunFunc.
-	if err := c.Run(c, c.Flags.Args()); err != nil {
+	positional := c.Flags.Args()
+
+	// Validate the positional arguments before doing anything else.
+	if c.Args != nil {
+		if err := c.Args(c, positional); err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			c.Flags.Usage()
+			os.Exit(2)
+		}
+	}
+
+	// Call our RunFunc, along with any lifecycle hooks registered on c or
+	// its ancestors.
+	if err := c.runWithHooks(positional); err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
 		os.Exit(1)
 	}
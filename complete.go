@@ -0,0 +1,264 @@
+package cmndr
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ShellCompDirective tells the invoking shell script how it should treat the
+// completion candidates printed by the hidden "__complete" command.
+//
+// Directives are combined with a bitwise OR, e.g.
+//
+//	ShellCompDirectiveNoSpace | ShellCompDirectiveNoFileComp
+type ShellCompDirective int
+
+const (
+	// ShellCompDirectiveError indicates that an error occurred while
+	// computing completions, and the shell should not attempt anything
+	// further.
+	ShellCompDirectiveError ShellCompDirective = 1 << iota
+
+	// ShellCompDirectiveNoSpace instructs the shell not to add a space
+	// after the completion, even when there is a single candidate.
+	ShellCompDirectiveNoSpace
+
+	// ShellCompDirectiveNoFileComp instructs the shell not to fall back
+	// to file completion when no candidates are returned.
+	ShellCompDirectiveNoFileComp
+
+	// ShellCompDirectiveFilterFileExt indicates that the returned
+	// candidates are file extensions that file completion should be
+	// filtered to.
+	ShellCompDirectiveFilterFileExt
+
+	// ShellCompDirectiveFilterDirs indicates that file completion should
+	// be limited to directories only.
+	ShellCompDirectiveFilterDirs
+
+	// ShellCompDirectiveDefault indicates that no special behaviour is
+	// requested.
+	ShellCompDirectiveDefault ShellCompDirective = 0
+)
+
+// newCompleteCmd returns the hidden "__complete" subcommand that the
+// generated shell completion scripts call at runtime. It is invoked as
+//
+//	$ prog __complete sub1 sub2 partial
+//
+// where the final argument is the (possibly empty) word currently being
+// completed. It prints one completion candidate per line, followed by a
+// final line of the form ":<directive>".
+func newCompleteCmd(root *Cmd) *Cmd {
+	return &Cmd{
+		Name:        "__complete",
+		Description: "Internal command used by shell completion scripts",
+		Run: func(cmd *Cmd, args []string) error {
+			completions, directive := completeArgs(root, args)
+			for _, c := range completions {
+				fmt.Println(c)
+			}
+			fmt.Println(":" + strconv.Itoa(int(directive)))
+			return nil
+		},
+	}
+}
+
+// completeArgs walks root's command tree following args, and returns the
+// completion candidates for the final element of args (the word currently
+// being completed).
+func completeArgs(root *Cmd, args []string) ([]string, ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, ShellCompDirectiveNoFileComp
+	}
+
+	toComplete := args[len(args)-1]
+	cmd := root
+	consumed := 0
+	for consumed < len(args)-1 {
+		sub, ok := cmd.Commands[args[consumed]]
+		if !ok {
+			break
+		}
+		cmd = sub
+		consumed++
+	}
+
+	if strings.HasPrefix(toComplete, "-") {
+		return completeFlags(cmd, toComplete), ShellCompDirectiveNoFileComp
+	}
+
+	if cmd.ValidArgsFunction != nil {
+		return cmd.ValidArgsFunction(cmd, args[consumed:len(args)-1], toComplete)
+	}
+
+	var completions []string
+	lowerToComplete := strings.ToLower(toComplete)
+	for _, va := range cmd.ValidArgs {
+		if strings.HasPrefix(strings.ToLower(va), lowerToComplete) {
+			completions = append(completions, va)
+		}
+	}
+	for name, sub := range cmd.Commands {
+		if strings.HasPrefix(name, "__") || sub.Hidden {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(name), lowerToComplete) {
+			completions = append(completions, name)
+		}
+	}
+	sort.Strings(completions)
+	return completions, ShellCompDirectiveDefault
+}
+
+// completeFlags returns the long-form flags of cmd whose name has toComplete
+// (with its leading dashes stripped) as a prefix.
+func completeFlags(cmd *Cmd, toComplete string) []string {
+	if cmd.Flags == nil {
+		return nil
+	}
+
+	stripped := strings.TrimLeft(toComplete, "-")
+	var flags []string
+	cmd.Flags.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, stripped) {
+			flags = append(flags, "--"+f.Name)
+		}
+	})
+	sort.Strings(flags)
+	return flags
+}
+
+// AddCompletionCmd installs the hidden "__complete" subcommand used by the
+// generated shell completion scripts, and a "completion" subcommand, with a
+// child for each supported shell, that users can run to generate those
+// scripts. It is analogous to the "help" subcommand that New() adds
+// automatically, but is opt-in since not every program wants to ship
+// completion scripts.
+func (c *Cmd) AddCompletionCmd() {
+	c.AddCmd(newCompleteCmd(c))
+
+	completion := &Cmd{
+		Name:        "completion",
+		Description: fmt.Sprintf("Generate a shell completion script for %s", c.Name),
+	}
+	generators := map[string]func(io.Writer) error{
+		"bash":       c.GenBashCompletion,
+		"zsh":        c.GenZshCompletion,
+		"fish":       c.GenFishCompletion,
+		"powershell": c.GenPowerShellCompletion,
+	}
+	for shell, gen := range generators {
+		gen := gen
+		completion.AddCmd(&Cmd{
+			Name:        shell,
+			Description: fmt.Sprintf("Generate a %s completion script for %s", shell, c.Name),
+			Run: func(cmd *Cmd, args []string) error {
+				return gen(os.Stdout)
+			},
+		})
+	}
+	c.AddCmd(completion)
+}
+
+// GenBashCompletion writes a bash completion script for c to w.
+func (c *Cmd) GenBashCompletion(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, bashCompletionTemplate, c.Name)
+	return bw.Flush()
+}
+
+// GenZshCompletion writes a zsh completion script for c to w.
+func (c *Cmd) GenZshCompletion(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, zshCompletionTemplate, c.Name)
+	return bw.Flush()
+}
+
+// GenFishCompletion writes a fish completion script for c to w.
+func (c *Cmd) GenFishCompletion(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, fishCompletionTemplate, c.Name)
+	return bw.Flush()
+}
+
+// GenPowerShellCompletion writes a PowerShell completion script for c to w.
+func (c *Cmd) GenPowerShellCompletion(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, powerShellCompletionTemplate, c.Name)
+	return bw.Flush()
+}
+
+const bashCompletionTemplate = `# bash completion for %[1]s -*- shell-script -*-
+
+__%[1]s_complete() {
+	local cur words cword out directive
+	cur="${COMP_WORDS[COMP_CWORD]}"
+	words=("${COMP_WORDS[@]:1:COMP_CWORD}")
+
+	out=$(%[1]s __complete "${words[@]}" 2>/dev/null)
+	directive=$(echo "${out}" | tail -n1 | sed 's/^://')
+	out=$(echo "${out}" | sed '$d')
+
+	if (( directive & 2 )); then
+		compopt -o nospace 2>/dev/null
+	fi
+	if (( directive & 4 )); then
+		compopt +o default 2>/dev/null
+	fi
+
+	COMPREPLY=($(compgen -W "${out}" -- "${cur}"))
+}
+
+complete -o default -F __%[1]s_complete %[1]s
+`
+
+const zshCompletionTemplate = `#compdef %[1]s
+
+_%[1]s_complete() {
+	local -a completions
+	local out directive line
+	out=$(%[1]s __complete "${words[@]:1}" 2>/dev/null)
+	directive=${${(f)out}[-1]#:}
+	completions=(${(f)out[1,-2]})
+
+	if (( directive & 4 )); then
+		compadd -- "${completions[@]}"
+	else
+		_describe 'command' completions
+	fi
+}
+
+compdef _%[1]s_complete %[1]s
+`
+
+const fishCompletionTemplate = `# fish completion for %[1]s
+
+function __%[1]s_complete
+	set -l out (%[1]s __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)
+	set -l directive $out[-1]
+	set -e out[-1]
+	string trim -- $out
+end
+
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`
+
+const powerShellCompletionTemplate = `# PowerShell completion for %[1]s
+
+Register-ArgumentCompleter -Native -CommandName %[1]s -ScriptBlock {
+	param($wordToComplete, $commandAst, $cursorPosition)
+
+	$words = $commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() }
+	$out = & %[1]s __complete @words $wordToComplete 2>$null
+	$out[0..($out.Length - 2)] | ForEach-Object {
+		[System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+	}
+}
+`
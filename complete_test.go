@@ -0,0 +1,74 @@
+package cmndr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteArgsSubcommand(t *testing.T) {
+	root := New("root", nil)
+	root.AddCmd(&Cmd{Name: "status"})
+	root.AddCmd(&Cmd{Name: "start"})
+	root.AddCmd(&Cmd{Name: "hidden", Hidden: true})
+
+	got, directive := completeArgs(root, []string{"st"})
+	want := []string{"start", "status"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+	if directive != ShellCompDirectiveDefault {
+		t.Errorf("directive = %v, want %v", directive, ShellCompDirectiveDefault)
+	}
+}
+
+func TestCompleteArgsFlags(t *testing.T) {
+	root := New("root", nil)
+	sub := &Cmd{Name: "sub"}
+	root.AddCmd(sub)
+	sub.Flags.String("explicit", "", "")
+	sub.Flags.Bool("exact", false, "")
+
+	got, directive := completeArgs(root, []string{"sub", "--ex"})
+	want := []string{"--exact", "--explicit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+	if directive != ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want %v", directive, ShellCompDirectiveNoFileComp)
+	}
+}
+
+func TestCompleteArgsValidArgsFunction(t *testing.T) {
+	root := New("root", nil)
+	sub := &Cmd{
+		Name: "sub",
+		ValidArgsFunction: func(cmd *Cmd, args []string, toComplete string) ([]string, ShellCompDirective) {
+			return []string{"from-func"}, ShellCompDirectiveNoSpace
+		},
+	}
+	root.AddCmd(sub)
+
+	got, directive := completeArgs(root, []string{"sub", ""})
+	want := []string{"from-func"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+	if directive != ShellCompDirectiveNoSpace {
+		t.Errorf("directive = %v, want %v", directive, ShellCompDirectiveNoSpace)
+	}
+}
+
+func TestCompleteArgsValidArgs(t *testing.T) {
+	root := New("root", nil)
+	sub := &Cmd{Name: "sub", ValidArgs: []string{"alice", "bob"}}
+	root.AddCmd(sub)
+
+	got, directive := completeArgs(root, []string{"sub", "al"})
+	want := []string{"alice"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+	if directive != ShellCompDirectiveDefault {
+		t.Errorf("directive = %v, want %v", directive, ShellCompDirectiveDefault)
+	}
+}
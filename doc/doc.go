@@ -0,0 +1,5 @@
+// Package doc generates reference documentation — Markdown, man pages, and
+// reStructuredText — from a *cmndr.Cmd tree, so that applications built on
+// cmndr can ship offline documentation without hand-maintaining it alongside
+// their command definitions.
+package doc
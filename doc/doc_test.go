@@ -0,0 +1,92 @@
+package doc
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/nesv/cmndr"
+)
+
+func buildTree() *cmndr.Cmd {
+	root := cmndr.New("mycli", nil)
+	sub := &cmndr.Cmd{Name: "sub"}
+	root.AddCmd(sub)
+	sub.AddCmd(&cmndr.Cmd{Name: "leaf", Description: "a leaf"})
+	return root
+}
+
+// checkSeeAlsoMatchesFiles asserts that every filename referenced by a
+// "SEE ALSO" style cross-reference (found by applying extractRefs to each
+// generated file's contents) corresponds to a file that was actually
+// written to dir.
+func checkSeeAlsoMatchesFiles(t *testing.T, dir string, extractRefs func(contents string) []string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	files := make(map[string]bool)
+	for _, e := range entries {
+		files[e.Name()] = true
+	}
+
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile(%s): %v", e.Name(), err)
+		}
+		for _, ref := range extractRefs(string(b)) {
+			if !files[ref] {
+				t.Errorf("%s references %q in its SEE ALSO section, but no such file was generated (have: %v)", e.Name(), ref, entries)
+			}
+		}
+	}
+}
+
+func TestGenManTreeSeeAlso(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenManTree(buildTree(), nil, dir); err != nil {
+		t.Fatalf("GenManTree: %v", err)
+	}
+	pattern := regexp.MustCompile(`\\fB([\w-]+)\\fR\((\d)\)`)
+	checkSeeAlsoMatchesFiles(t, dir, func(contents string) []string {
+		var refs []string
+		for _, m := range pattern.FindAllStringSubmatch(contents, -1) {
+			refs = append(refs, m[1]+"."+m[2])
+		}
+		return refs
+	})
+}
+
+func TestGenMarkdownTreeSeeAlso(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenMarkdownTree(buildTree(), dir); err != nil {
+		t.Fatalf("GenMarkdownTree: %v", err)
+	}
+	pattern := regexp.MustCompile(`\]\(([\w.]+)\)`)
+	checkSeeAlsoMatchesFiles(t, dir, func(contents string) []string {
+		var refs []string
+		for _, m := range pattern.FindAllStringSubmatch(contents, -1) {
+			refs = append(refs, m[1])
+		}
+		return refs
+	})
+}
+
+func TestGenReSTTreeSeeAlso(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenReSTTree(buildTree(), dir); err != nil {
+		t.Fatalf("GenReSTTree: %v", err)
+	}
+	pattern := regexp.MustCompile("<([\\w.]+)>`_")
+	checkSeeAlsoMatchesFiles(t, dir, func(contents string) []string {
+		var refs []string
+		for _, m := range pattern.FindAllStringSubmatch(contents, -1) {
+			refs = append(refs, m[1])
+		}
+		return refs
+	})
+}
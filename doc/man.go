@@ -0,0 +1,92 @@
+package doc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/nesv/cmndr"
+)
+
+// GenManHeader holds the metadata that appears in the header of a generated
+// man page.
+type GenManHeader struct {
+	// Title is the uppercase title shown in the page header, e.g.
+	// "MYCLI-SUB-LEAF". If empty, it is derived from the command name.
+	Title string
+
+	// Section is the man section number, e.g. "1" for user commands. If
+	// empty, "1" is used.
+	Section string
+
+	// Source identifies the package or distribution the page came from,
+	// e.g. "mycli 1.2.0".
+	Source string
+
+	// Manual is the title of the manual the page belongs to, e.g.
+	// "User Commands".
+	Manual string
+
+	// Date is the page's generation date, formatted however the caller
+	// prefers.
+	Date string
+}
+
+// GenManTree walks cmd and all of its descendants, writing one roff-format
+// man page per command into dir, named "<dashed-command-name>.<section>",
+// e.g. "mycli-sub-leaf.1".
+func GenManTree(cmd *cmndr.Cmd, header *GenManHeader, dir string) error {
+	if header == nil {
+		header = &GenManHeader{}
+	}
+	return walk(cmd, nil, func(cmd *cmndr.Cmd, names []string) error {
+		return genMan(cmd, names, header, dir)
+	})
+}
+
+func genMan(cmd *cmndr.Cmd, names []string, header *GenManHeader, dir string) error {
+	section := header.Section
+	if section == "" {
+		section = "1"
+	}
+	dashed := strings.Join(names, "-")
+	title := header.Title
+	if title == "" {
+		title = strings.ToUpper(dashed)
+	}
+	full := commandPath(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, ".TH %q %q %q %q %q\n", title, section, header.Date, header.Source, header.Manual)
+
+	fmt.Fprintln(&buf, ".SH NAME")
+	fmt.Fprintf(&buf, "%s \\- %s\n", dashed, cmd.Description)
+
+	fmt.Fprintln(&buf, ".SH SYNOPSIS")
+	fmt.Fprintf(&buf, ".B %s\n", synopsis(cmd, full))
+
+	fmt.Fprintln(&buf, ".SH DESCRIPTION")
+	fmt.Fprintln(&buf, cmd.Description)
+
+	if hasFlags(cmd) {
+		fmt.Fprintln(&buf, ".SH OPTIONS")
+		cmd.Flags.VisitAll(func(f *flag.Flag) {
+			fmt.Fprintf(&buf, ".TP\n\\-%s\n%s\n", f.Name, f.Usage)
+		})
+	}
+
+	if entries := seeAlsoWith(cmd, names, func(names []string) string { return strings.Join(names, "-") }); len(entries) > 0 {
+		fmt.Fprintln(&buf, ".SH SEE ALSO")
+		parts := make([]string, len(entries))
+		for i, e := range entries {
+			parts[i] = fmt.Sprintf("\\fB%s\\fR(%s)", e.filename, section)
+		}
+		fmt.Fprintln(&buf, strings.Join(parts, ", "))
+	}
+
+	filename := filepath.Join(dir, fmt.Sprintf("%s.%s", dashed, section))
+	return ioutil.WriteFile(filename, buf.Bytes(), 0644)
+}
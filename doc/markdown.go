@@ -0,0 +1,53 @@
+package doc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/nesv/cmndr"
+)
+
+// GenMarkdownTree walks cmd and all of its descendants, writing one
+// Markdown file per command into dir. Each file is named after the
+// command's full path, with spaces replaced by underscores, e.g.
+// "mycli_sub_leaf.md".
+func GenMarkdownTree(cmd *cmndr.Cmd, dir string) error {
+	return walk(cmd, nil, func(cmd *cmndr.Cmd, names []string) error {
+		return genMarkdown(cmd, names, dir)
+	})
+}
+
+func genMarkdown(cmd *cmndr.Cmd, names []string, dir string) error {
+	full := commandPath(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "## %s\n\n", full)
+	fmt.Fprintf(&buf, "%s\n\n", cmd.Description)
+
+	fmt.Fprintln(&buf, "### Synopsis")
+	fmt.Fprintf(&buf, "\n```\n%s\n```\n\n", synopsis(cmd, full))
+
+	if hasFlags(cmd) {
+		fmt.Fprintln(&buf, "### Flags")
+		fmt.Fprintln(&buf, "\n```")
+		cmd.Flags.VisitAll(func(f *flag.Flag) {
+			fmt.Fprintf(&buf, "  -%s\t%s\n", f.Name, f.Usage)
+		})
+		fmt.Fprintln(&buf, "```")
+		fmt.Fprintln(&buf)
+	}
+
+	if entries := seeAlso(cmd, names); len(entries) > 0 {
+		fmt.Fprintln(&buf, "### See also")
+		fmt.Fprintln(&buf)
+		for _, e := range entries {
+			fmt.Fprintf(&buf, "* [%s](%s.md)\n", e.full, e.filename)
+		}
+	}
+
+	filename := filepath.Join(dir, commandFilename(names)+".md")
+	return ioutil.WriteFile(filename, buf.Bytes(), 0644)
+}
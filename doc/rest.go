@@ -0,0 +1,57 @@
+package doc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/nesv/cmndr"
+)
+
+// GenReSTTree walks cmd and all of its descendants, writing one
+// reStructuredText file per command into dir, e.g. "mycli_sub_leaf.rst".
+func GenReSTTree(cmd *cmndr.Cmd, dir string) error {
+	return walk(cmd, nil, func(cmd *cmndr.Cmd, names []string) error {
+		return genReST(cmd, names, dir)
+	})
+}
+
+func genReST(cmd *cmndr.Cmd, names []string, dir string) error {
+	full := commandPath(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, full)
+	fmt.Fprintln(&buf, strings.Repeat("=", len(full)))
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, cmd.Description)
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "Synopsis")
+	fmt.Fprintln(&buf, "--------")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "::\n\n    %s\n\n", synopsis(cmd, full))
+
+	if hasFlags(cmd) {
+		fmt.Fprintln(&buf, "Options")
+		fmt.Fprintln(&buf, "-------")
+		fmt.Fprintln(&buf)
+		cmd.Flags.VisitAll(func(f *flag.Flag) {
+			fmt.Fprintf(&buf, "``-%s``\n    %s\n\n", f.Name, f.Usage)
+		})
+	}
+
+	if entries := seeAlso(cmd, names); len(entries) > 0 {
+		fmt.Fprintln(&buf, "See also")
+		fmt.Fprintln(&buf, "--------")
+		fmt.Fprintln(&buf)
+		for _, e := range entries {
+			fmt.Fprintf(&buf, "* `%s <%s.rst>`_\n", e.full, e.filename)
+		}
+	}
+
+	filename := filepath.Join(dir, commandFilename(names)+".rst")
+	return ioutil.WriteFile(filename, buf.Bytes(), 0644)
+}
@@ -0,0 +1,110 @@
+package doc
+
+import (
+	"flag"
+	"sort"
+	"strings"
+
+	"github.com/nesv/cmndr"
+)
+
+// commandPath returns the full, space-separated path of names from the root
+// command down to the command being documented, e.g. "mycli sub leaf".
+func commandPath(names []string) string {
+	return strings.Join(names, " ")
+}
+
+// commandFilename returns the filename stem (without extension) used for a
+// command's documentation page, e.g. "mycli_sub_leaf".
+func commandFilename(names []string) string {
+	return strings.Join(names, "_")
+}
+
+// synopsis builds a one-line usage summary for cmd.
+func synopsis(cmd *cmndr.Cmd, full string) string {
+	s := full
+	if len(sortedSubcommands(cmd)) > 0 {
+		s += " [command]"
+	}
+	return s + " [flags]"
+}
+
+// sortedSubcommands returns the names of cmd's subcommands, sorted, skipping
+// internal (double-underscore prefixed) commands such as "__complete", and
+// any subcommand marked Hidden.
+func sortedSubcommands(cmd *cmndr.Cmd) []string {
+	var names []string
+	for name, sub := range cmd.Commands {
+		if strings.HasPrefix(name, "__") || sub.Hidden {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// walk recursively invokes fn for cmd and every one of its descendants,
+// tracking the chain of names from the root down to the command currently
+// being visited.
+func walk(cmd *cmndr.Cmd, names []string, fn func(cmd *cmndr.Cmd, names []string) error) error {
+	path := make([]string, len(names)+1)
+	copy(path, names)
+	path[len(names)] = cmd.Name
+
+	if err := fn(cmd, path); err != nil {
+		return err
+	}
+	for _, name := range sortedSubcommands(cmd) {
+		if err := walk(cmd.Commands[name], path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seeAlsoEntry is a single link in a "SEE ALSO" section: a command's full
+// name, and the filename stem of its documentation page.
+type seeAlsoEntry struct {
+	full     string
+	filename string
+}
+
+// seeAlso returns the entries for cmd's "SEE ALSO" section: its parent (if
+// any), followed by its subcommands. Filenames are joined with underscores,
+// matching GenMarkdownTree/GenReSTTree; GenManTree uses seeAlsoWith instead,
+// since its pages are named with dashes.
+func seeAlso(cmd *cmndr.Cmd, names []string) []seeAlsoEntry {
+	return seeAlsoWith(cmd, names, commandFilename)
+}
+
+// seeAlsoWith is like seeAlso, but builds each entry's filename with
+// filename instead of always joining with underscores.
+func seeAlsoWith(cmd *cmndr.Cmd, names []string, filename func([]string) string) []seeAlsoEntry {
+	var entries []seeAlsoEntry
+	if len(names) > 1 {
+		parentNames := names[:len(names)-1]
+		entries = append(entries, seeAlsoEntry{
+			full:     commandPath(parentNames),
+			filename: filename(parentNames),
+		})
+	}
+	for _, name := range sortedSubcommands(cmd) {
+		childNames := append(append([]string{}, names...), name)
+		entries = append(entries, seeAlsoEntry{
+			full:     commandPath(childNames),
+			filename: filename(childNames),
+		})
+	}
+	return entries
+}
+
+// hasFlags reports whether cmd has any registered flags.
+func hasFlags(cmd *cmndr.Cmd) bool {
+	if cmd.Flags == nil {
+		return false
+	}
+	found := false
+	cmd.Flags.VisitAll(func(_ *flag.Flag) { found = true })
+	return found
+}
@@ -0,0 +1,128 @@
+package cmndr
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Group describes a named bucket that a command's subcommands can be
+// grouped into for display purposes. Register groups on a command with
+// AddGroup, then assign subcommands to one by setting their GroupID field.
+type Group struct {
+	// ID is referenced by a subcommand's GroupID field.
+	ID string
+
+	// Title is the heading printed above the group's commands in help
+	// output.
+	Title string
+}
+
+// AddGroup registers one or more command groups on c, in the order they
+// should appear in help output.
+func (c *Cmd) AddGroup(groups ...*Group) {
+	c.Groups = append(c.Groups, groups...)
+}
+
+// additionalCommandsGroupID and helpCommandsGroupID are the IDs of the two
+// buckets that printSubcommands always reserves, regardless of what groups
+// a command has registered: one for commands left ungrouped, and one for
+// the commands, such as "help" and "completion", that exist only to help
+// users navigate the command-line interface.
+const (
+	additionalCommandsGroupID = ""
+	helpCommandsGroupID       = "\x00help"
+)
+
+// helpCommandNames holds the names of commands that are bucketed into
+// "Help Commands" rather than "Additional Commands", regardless of their
+// GroupID.
+var helpCommandNames = map[string]bool{
+	"help":       true,
+	"completion": true,
+}
+
+// visibleSubcommandNames returns the names of c's subcommands that should
+// appear in help output, sorted: neither internal (double-underscore
+// prefixed, such as "__complete") nor explicitly Hidden.
+func visibleSubcommandNames(c *Cmd) []string {
+	var names []string
+	for name, sub := range c.Commands {
+		if strings.HasPrefix(name, "__") || sub.Hidden {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printSubcommands is a helper function, used when printing a usage or help
+// message; it prints c's visible subcommands, bucketed by their GroupID
+// under the matching Group's Title, with ungrouped commands (including
+// those whose GroupID matches no registered Group) falling under
+// "Additional Commands" and "help"/"completion" falling under their own
+// "Help Commands" bucket. Deprecated subcommands are annotated with their
+// deprecation message.
+func printSubcommands(c *Cmd) {
+	if c.Commands == nil {
+		return
+	}
+
+	titles := map[string]string{
+		additionalCommandsGroupID: "Additional Commands",
+		helpCommandsGroupID:       "Help Commands",
+	}
+	for _, g := range c.Groups {
+		titles[g.ID] = g.Title
+	}
+
+	names := make(map[string][]string)
+	for _, name := range visibleSubcommandNames(c) {
+		sub := c.Commands[name]
+		id := sub.GroupID
+		switch {
+		case helpCommandNames[name]:
+			id = helpCommandsGroupID
+		case id == "":
+			id = additionalCommandsGroupID
+		default:
+			if _, ok := titles[id]; !ok {
+				id = additionalCommandsGroupID
+			}
+		}
+		names[id] = append(names[id], name)
+	}
+
+	// Print registered groups in registration order, then "Additional
+	// Commands", then "Help Commands".
+	var order []string
+	for _, g := range c.Groups {
+		order = append(order, g.ID)
+	}
+	order = append(order, additionalCommandsGroupID, helpCommandsGroupID)
+
+	tw := tabwriter.NewWriter(os.Stderr, 0, 4, 1, ' ', 0)
+	defer tw.Flush()
+	for _, id := range order {
+		group := names[id]
+		if len(group) == 0 {
+			continue
+		}
+		title := titles[id]
+		if title == "" {
+			title = id
+		}
+		fmt.Fprintf(tw, "\n%s\n", title)
+		for _, name := range group {
+			sub := c.Commands[name]
+			descr := sub.Description
+			if sub.Deprecated != "" {
+				descr = fmt.Sprintf("%s (deprecated: %s)", descr, sub.Deprecated)
+			}
+			fmt.Fprintf(tw, "\t\t%s\t%s\n", name, descr)
+		}
+	}
+}
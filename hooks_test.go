@@ -0,0 +1,97 @@
+package cmndr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunWithHooksOrder(t *testing.T) {
+	var order []string
+	record := func(name string) RunFunc {
+		return func(cmd *Cmd, args []string) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	root := &Cmd{
+		Name:               "root",
+		PersistentPreRunE:  record("root-pre"),
+		PersistentPostRunE: record("root-post"),
+	}
+	leaf := &Cmd{
+		Name:     "leaf",
+		PreRun:   func(cmd *Cmd, args []string) { order = append(order, "leaf-PreRun") },
+		PreRunE:  record("leaf-PreRunE"),
+		Run:      record("leaf-Run"),
+		PostRun:  func(cmd *Cmd, args []string) { order = append(order, "leaf-PostRun") },
+		PostRunE: record("leaf-PostRunE"),
+	}
+	root.AddCmd(leaf)
+
+	if err := leaf.runWithHooks(nil); err != nil {
+		t.Fatalf("runWithHooks: %v", err)
+	}
+
+	want := []string{
+		"root-pre", "leaf-PreRun", "leaf-PreRunE", "leaf-Run",
+		"leaf-PostRun", "leaf-PostRunE", "root-post",
+	}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRunWithHooksNearestPersistentWins(t *testing.T) {
+	var called string
+	root := &Cmd{
+		Name: "root",
+		PersistentPreRunE: func(cmd *Cmd, args []string) error {
+			called = "root"
+			return nil
+		},
+	}
+	leaf := &Cmd{
+		Name: "leaf",
+		PersistentPreRunE: func(cmd *Cmd, args []string) error {
+			called = "leaf"
+			return nil
+		},
+		Run: func(cmd *Cmd, args []string) error { return nil },
+	}
+	root.AddCmd(leaf)
+
+	if err := leaf.runWithHooks(nil); err != nil {
+		t.Fatalf("runWithHooks: %v", err)
+	}
+	if called != "leaf" {
+		t.Errorf("called = %q, want %q (the nearest PersistentPreRunE should win)", called, "leaf")
+	}
+}
+
+func TestRunWithHooksShortCircuitsOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+	ran := false
+	leaf := &Cmd{
+		Name: "leaf",
+		PreRunE: func(cmd *Cmd, args []string) error {
+			return wantErr
+		},
+		Run: func(cmd *Cmd, args []string) error {
+			ran = true
+			return nil
+		},
+	}
+
+	if err := leaf.runWithHooks(nil); err != wantErr {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+	if ran {
+		t.Error("Run should not have been called after PreRunE returned an error")
+	}
+}
@@ -0,0 +1,67 @@
+package cmndr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergePersistentFlags(t *testing.T) {
+	root := New("root", nil)
+	root.PersistentFlags().String("config", "default", "config file")
+
+	sub := &Cmd{Name: "sub"}
+	root.AddCmd(sub)
+	sub.Flags.String("x", "", "local flag")
+
+	mergePersistentFlags(sub)
+
+	if err := sub.Flags.Parse([]string{"-config", "custom", "-x", "y"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := sub.Flags.Lookup("config").Value.String(); got != "custom" {
+		t.Errorf("config = %q, want %q", got, "custom")
+	}
+	if got := sub.Flags.Lookup("x").Value.String(); got != "y" {
+		t.Errorf("x = %q, want %q", got, "y")
+	}
+}
+
+func TestMergePersistentFlagsNearestWins(t *testing.T) {
+	root := New("root", nil)
+	root.PersistentFlags().String("env", "root-default", "")
+
+	mid := &Cmd{Name: "mid"}
+	root.AddCmd(mid)
+	mid.PersistentFlags().String("env", "mid-default", "")
+
+	leaf := &Cmd{Name: "leaf"}
+	mid.AddCmd(leaf)
+
+	mergePersistentFlags(leaf)
+
+	f := leaf.Flags.Lookup("env")
+	if f == nil {
+		t.Fatal("env was not merged into leaf.Flags")
+	}
+	if f.DefValue != "mid-default" {
+		t.Errorf("DefValue = %q, want %q (nearest ancestor should win)", f.DefValue, "mid-default")
+	}
+}
+
+func TestSubcommandUsageShowsGlobalFlags(t *testing.T) {
+	root := New("root", nil)
+	root.PersistentFlags().String("config", "", "config file")
+
+	sub := &Cmd{Name: "sub", Description: "a subcommand"}
+	root.AddCmd(sub)
+	sub.Flags.String("x", "", "local flag")
+
+	out := captureStderr(t, func() { sub.Flags.Usage() })
+
+	if !strings.Contains(out, "Global Flags") {
+		t.Errorf("usage output missing Global Flags section:\n%s", out)
+	}
+	if !strings.Contains(out, "-config") {
+		t.Errorf("usage output missing inherited -config flag:\n%s", out)
+	}
+}
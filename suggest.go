@@ -0,0 +1,93 @@
+package cmndr
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// defaultSuggestionsMinimumDistance is used when a Cmd leaves
+// SuggestionsMinimumDistance at its zero value.
+const defaultSuggestionsMinimumDistance = 2
+
+// levenshtein computes the case-insensitive Levenshtein edit distance
+// between a and b, using the classic two-row dynamic programming
+// algorithm: dp[i][j] = min(dp[i-1][j]+1, dp[i][j-1]+1, dp[i-1][j-1] +
+// (a[i-1] != b[j-1])).
+func levenshtein(a, b string) int {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// suggestions returns the names of c's subcommands that are likely
+// corrections for token: every subcommand whose name has token as a
+// case-insensitive prefix, plus every subcommand within c's suggestion
+// distance threshold.
+func suggestions(c *Cmd, token string) []string {
+	if c.DisableSuggestions || c.Commands == nil {
+		return nil
+	}
+
+	threshold := c.SuggestionsMinimumDistance
+	if threshold <= 0 {
+		threshold = defaultSuggestionsMinimumDistance
+	}
+
+	lowerToken := strings.ToLower(token)
+	var names []string
+	for name, sub := range c.Commands {
+		if strings.HasPrefix(name, "__") || sub.Hidden {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(name), lowerToken) || levenshtein(token, name) <= threshold {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printSuggestions prints a "Did you mean" block listing c's subcommands
+// that are likely corrections for token, if there are any.
+func printSuggestions(c *Cmd, token string) {
+	names := suggestions(c, token)
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "\nDid you mean this?")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "\t%s\n", name)
+	}
+}
@@ -0,0 +1,66 @@
+package cmndr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"Status", "status", 0},
+		{"statsu", "status", 2},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	root := New("root", nil)
+	root.AddCmd(&Cmd{Name: "status"})
+	root.AddCmd(&Cmd{Name: "start"})
+	root.AddCmd(&Cmd{Name: "stop"})
+	root.AddCmd(&Cmd{Name: "hidden", Hidden: true})
+
+	got := suggestions(root, "statsu")
+	want := []string{"status"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("suggestions(%q) = %v, want %v", "statsu", got, want)
+	}
+
+	if names := suggestions(root, "hidde"); len(names) != 0 {
+		t.Errorf("suggestions should not surface hidden commands, got %v", names)
+	}
+
+	disabled := &Cmd{Name: "sub", DisableSuggestions: true}
+	disabled.AddCmd(&Cmd{Name: "status"})
+	if names := suggestions(disabled, "statsu"); names != nil {
+		t.Errorf("suggestions should respect DisableSuggestions, got %v", names)
+	}
+}
+
+func TestPrintSuggestions(t *testing.T) {
+	root := New("root", nil)
+	root.AddCmd(&Cmd{Name: "status"})
+
+	out := captureStderr(t, func() { printSuggestions(root, "statsu") })
+	if !strings.Contains(out, "Did you mean this?") || !strings.Contains(out, "status") {
+		t.Errorf("printSuggestions output missing suggestion, got:\n%s", out)
+	}
+
+	out = captureStderr(t, func() { printSuggestions(root, "zzz") })
+	if out != "" {
+		t.Errorf("printSuggestions should print nothing when there are no suggestions, got:\n%s", out)
+	}
+}
@@ -0,0 +1,76 @@
+package cmndr
+
+import (
+	"flag"
+	"io"
+	"text/template"
+)
+
+// HasAvailableSubCommands reports whether c has any subcommands that would
+// be listed in its usage or help output, i.e. it is not "__"-prefixed and
+// not Hidden. UsageTemplate and HelpTemplate authors call this as
+// {{if .HasAvailableSubCommands}}.
+func (c *Cmd) HasAvailableSubCommands() bool {
+	return len(visibleSubcommandNames(c)) > 0
+}
+
+// LocalFlags returns c's own flag set, c.Flags. Once persistent flags have
+// been merged into it (as ExecArgs does before parsing, and the default
+// usage message does before rendering a UsageTemplate), it holds the
+// inherited persistent flags as well as the flags registered directly on
+// c. UsageTemplate and HelpTemplate authors call this as {{.LocalFlags}}.
+func (c *Cmd) LocalFlags() *flag.FlagSet {
+	return c.Flags
+}
+
+// InheritedFlags returns the persistent flags that c inherited from its
+// ancestors, i.e. the flags that appear in the "Global Flags" section of
+// the default usage message. UsageTemplate and HelpTemplate authors call
+// this as {{.InheritedFlags}}.
+func (c *Cmd) InheritedFlags() []*flag.Flag {
+	seen := make(map[string]bool)
+	var flags []*flag.Flag
+	for p := c.parent; p != nil; p = p.parent {
+		if p.persistentFlags == nil {
+			continue
+		}
+		p.persistentFlags.VisitAll(func(f *flag.Flag) {
+			if seen[f.Name] {
+				return
+			}
+			seen[f.Name] = true
+			flags = append(flags, f)
+		})
+	}
+	return flags
+}
+
+// templateFuncs returns the extra functions made available to a Cmd's
+// UsageTemplate and HelpTemplate, beyond the methods Cmd exposes directly
+// (HasAvailableSubCommands, LocalFlags, InheritedFlags). subCommands is a
+// plain function, rather than a method on *Cmd, since Cmd already exposes
+// a Commands field whose name would otherwise collide with it; template
+// authors call it as {{subCommands .}} rather than {{.SubCommands}}.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"subCommands": func(c *Cmd) []*Cmd {
+			names := visibleSubcommandNames(c)
+			cmds := make([]*Cmd, len(names))
+			for i, name := range names {
+				cmds[i] = c.Commands[name]
+			}
+			return cmds
+		},
+	}
+}
+
+// renderTemplate parses text as a text/template named name, with access to
+// the template functions returned by templateFuncs, and executes it with
+// data as the data value, writing the result to w.
+func renderTemplate(w io.Writer, name, text string, data *Cmd) error {
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
@@ -0,0 +1,65 @@
+package cmndr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHasAvailableSubCommands(t *testing.T) {
+	bare := &Cmd{Name: "bare"}
+	if bare.HasAvailableSubCommands() {
+		t.Error("a Cmd with no registered subcommands should report none available")
+	}
+
+	root := New("root", nil)
+	if !root.HasAvailableSubCommands() {
+		t.Error("New() registers a \"help\" subcommand, so root should report one available")
+	}
+}
+
+func TestLocalFlags(t *testing.T) {
+	root := New("root", nil)
+	if root.LocalFlags() != root.Flags {
+		t.Error("LocalFlags should return c.Flags")
+	}
+}
+
+func TestInheritedFlags(t *testing.T) {
+	root := New("root", nil)
+	root.PersistentFlags().String("config", "", "")
+
+	sub := &Cmd{Name: "sub"}
+	root.AddCmd(sub)
+
+	flags := sub.InheritedFlags()
+	if len(flags) != 1 || flags[0].Name != "config" {
+		t.Errorf("InheritedFlags() = %v, want a single \"config\" flag", flags)
+	}
+}
+
+func TestRenderTemplateDotStyleFuncs(t *testing.T) {
+	root := New("root", nil)
+	root.PersistentFlags().String("config", "", "")
+	root.AddCmd(&Cmd{Name: "sub"})
+
+	var buf bytes.Buffer
+	tmpl := `{{if .HasAvailableSubCommands}}subs:{{range subCommands .}} {{.Name}}{{end}}{{end}}
+local: {{with .LocalFlags}}{{.Name}}{{end}}
+inherited: {{len .InheritedFlags}}`
+
+	if err := renderTemplate(&buf, "t", tmpl, root); err != nil {
+		t.Fatalf("renderTemplate: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "subs: help sub") {
+		t.Errorf("output missing rendered subcommand name, got:\n%s", out)
+	}
+	if !strings.Contains(out, "local: root") {
+		t.Errorf("output missing LocalFlags name, got:\n%s", out)
+	}
+	if !strings.Contains(out, "inherited: 0") {
+		t.Errorf("root should have no inherited flags, got:\n%s", out)
+	}
+}
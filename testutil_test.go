@@ -0,0 +1,33 @@
+package cmndr
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+// captureStderr redirects os.Stderr for the duration of fn, returning
+// everything written to it. Several Cmd methods (newUsage, in particular)
+// write straight to os.Stderr, so tests exercising them need this to
+// observe the output.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	orig := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	os.Stderr = w
+	defer func() { os.Stderr = orig }()
+
+	fn()
+
+	w.Close()
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("io.Copy: %v", err)
+	}
+	return buf.String()
+}